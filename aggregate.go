@@ -0,0 +1,195 @@
+package eta
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Aggregator calculates processing speed in items per second
+// from speeds of stored periods ordered from oldest to newest
+type Aggregator func(rates []float64) float64
+
+// MeanRate returns arithmetic mean of rates
+func MeanRate(rates []float64) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, rate := range rates {
+		sum += rate
+	}
+
+	return sum / float64(len(rates))
+}
+
+// HarmonicMeanRate returns harmonic mean of non-zero rates
+func HarmonicMeanRate(rates []float64) float64 {
+	var sum float64
+	count := 0
+
+	for _, rate := range rates {
+		if rate <= 0 {
+			continue
+		}
+
+		sum += 1 / rate
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return float64(count) / sum
+}
+
+// MaxRate returns maximum of rates
+func MaxRate(rates []float64) float64 {
+	var max float64
+	for _, rate := range rates {
+		max = math.Max(max, rate)
+	}
+
+	return max
+}
+
+// TrimmedMeanRate returns aggregator which calculates mean of rates
+// after dropping given fraction of lowest and highest values.
+// Fraction is limited to [0, 0.5), values from 0.5 leave only median rates.
+func TrimmedMeanRate(trim float64) Aggregator {
+	if !(trim > 0) {
+		trim = 0
+	} else if trim >= 0.5 {
+		trim = math.Nextafter(0.5, 0)
+	}
+
+	return func(rates []float64) float64 {
+		sorted := append([]float64(nil), rates...)
+		sort.Float64s(sorted)
+
+		n := int(float64(len(sorted)) * trim)
+		if 2*n >= len(sorted) {
+			return MeanRate(sorted)
+		}
+
+		return MeanRate(sorted[n : len(sorted)-n])
+	}
+}
+
+// rates returns processing speeds of stored periods in items per second
+func (ec *Calculator) rates() []float64 {
+	rates := make([]float64, len(ec.stats))
+	for i, processed := range ec.stats {
+		rates[i] = float64(processed) / ec.periodDuration.Seconds()
+	}
+
+	// First period of run is shorter than others if run started inside it
+	if len(rates) > 0 && ec.firstPeriod > 0 {
+		rates[0] = float64(ec.stats[0]) / ec.firstPeriod.Seconds()
+	}
+
+	return rates
+}
+
+// customCycleTime returns cycle time based on processing speed calculated by aggregator
+func (ec *Calculator) customCycleTime(aggregator Aggregator) time.Duration {
+	if aggregator == nil {
+		return ec.averageCycleTime()
+	}
+
+	rate := aggregator(ec.rates())
+	if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return time.Duration(0)
+	}
+
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// Custom returns ETA based on processing speed calculated by Aggregator
+// over last periods. Average is used if Aggregator is not set.
+func (ec *Calculator) Custom() time.Time {
+	now := ec.now()
+
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	return ec.in(ec.custom(now, ec.Aggregator))
+}
+
+// custom returns ETA based on processing speed calculated by aggregator,
+// must be called with lock held
+func (ec *Calculator) custom(now time.Time, aggregator Aggregator) time.Time {
+	if len(ec.stats) == 0 {
+		return ec.eta(now)
+	}
+
+	customCycleTime := ec.customCycleTime(aggregator)
+	if customCycleTime == 0 {
+		return time.Time{}
+	}
+
+	return ec.project(now, customCycleTime)
+}
+
+// AddEstimator registers named estimator based on processing speed calculated by aggregator
+func (ec *Calculator) AddEstimator(name string, aggregator Aggregator) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if ec.estimators == nil {
+		ec.estimators = make(map[string]Aggregator)
+	}
+	ec.estimators[name] = aggregator
+}
+
+// Estimate returns ETA of named estimator registered by AddEstimator,
+// zero time if there is no such estimator
+func (ec *Calculator) Estimate(name string) time.Time {
+	now := ec.now()
+
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	aggregator, ok := ec.estimators[name]
+	if !ok {
+		return time.Time{}
+	}
+
+	return ec.in(ec.custom(now, aggregator))
+}
+
+// MinPeriodRate returns minimum processing speed of stored periods in items per second
+func (ec *Calculator) MinPeriodRate() float64 {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	rates := ec.rates()
+	if len(rates) == 0 {
+		return 0
+	}
+
+	min := rates[0]
+	for _, rate := range rates[1:] {
+		min = math.Min(min, rate)
+	}
+
+	return min
+}
+
+// MaxPeriodRate returns maximum processing speed of stored periods in items per second
+func (ec *Calculator) MaxPeriodRate() float64 {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	return MaxRate(ec.rates())
+}
+
+// MeanPeriodRate returns mean processing speed of stored periods in items per second
+func (ec *Calculator) MeanPeriodRate() float64 {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	return MeanRate(ec.rates())
+}
@@ -0,0 +1,24 @@
+package eta
+
+import "testing"
+
+func TestTrimmedMeanRate(t *testing.T) {
+	rates := []float64{1, 2, 3, 10}
+
+	tests := []struct {
+		trim float64
+		want float64
+	}{
+		{-1, 4},
+		{0, 4},
+		{0.25, 2.5},
+		{0.5, 2.5},
+		{1, 2.5},
+	}
+
+	for _, test := range tests {
+		if got := TrimmedMeanRate(test.trim)(rates); got != test.want {
+			t.Errorf("TrimmedMeanRate(%v) = %v, want %v", test.trim, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package eta
+
+import "time"
+
+// Bucket represents coarse estimate of remaining time
+type Bucket int
+
+const (
+	BucketUnknown Bucket = iota
+	BucketDone
+	BucketMoments
+	BucketMinutes
+	BucketUnderAnHour
+	BucketHours
+	BucketMoreThanADay
+)
+
+// String returns human readable bucket description
+func (b Bucket) String() string {
+	switch b {
+	case BucketDone:
+		return "done"
+	case BucketMoments:
+		return "moments"
+	case BucketMinutes:
+		return "minutes"
+	case BucketUnderAnHour:
+		return "under an hour"
+	case BucketHours:
+		return "hours"
+	case BucketMoreThanADay:
+		return "more than a day"
+	default:
+		return "unknown"
+	}
+}
+
+// BucketOf returns bucket of remaining duration
+func BucketOf(remaining time.Duration) Bucket {
+	switch {
+	case remaining <= 0:
+		return BucketDone
+	case remaining < time.Minute:
+		return BucketMoments
+	case remaining < 15*time.Minute:
+		return BucketMinutes
+	case remaining < time.Hour:
+		return BucketUnderAnHour
+	case remaining < 24*time.Hour:
+		return BucketHours
+	default:
+		return BucketMoreThanADay
+	}
+}
+
+// Bucket returns coarse bucket of remaining time based on total time
+// and total processed items count
+func (ec *Calculator) Bucket() Bucket {
+	s := ec.peek()
+
+	if s.Remaining() == 0 {
+		return BucketDone
+	}
+
+	if s.Eta.IsZero() {
+		return BucketUnknown
+	}
+
+	remaining := s.Eta.Sub(s.Time)
+	if remaining <= 0 {
+		return BucketMoments
+	}
+
+	return BucketOf(remaining)
+}
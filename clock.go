@@ -0,0 +1,102 @@
+package eta
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// coarseClock represents clock shared between calculators
+// which is updated by ticker instead of calling time.Now on every read
+type coarseClock struct {
+	now int64 // unix nanoseconds, must be accessed atomically
+
+	mu    sync.Mutex
+	users int
+	stop  chan struct{}
+}
+
+var sharedClock = &coarseClock{}
+
+// acquire starts clock ticker for first user
+func (c *coarseClock) acquire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.users++
+	if c.users > 1 {
+		return
+	}
+
+	atomic.StoreInt64(&c.now, time.Now().UnixNano())
+	c.stop = make(chan struct{})
+
+	go c.run(c.stop)
+}
+
+// release stops clock ticker after last user
+func (c *coarseClock) release() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.users--
+	if c.users == 0 {
+		close(c.stop)
+	}
+
+	return nil
+}
+
+func (c *coarseClock) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(coarseClockResolution)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case t := <-ticker.C:
+			atomic.StoreInt64(&c.now, t.UnixNano())
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Now returns last time sampled by ticker
+func (c *coarseClock) Now() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.now))
+}
+
+// UseCoarseClock makes calculator sample time from shared coarse ticker
+// instead of calling time.Now on every operation.
+// Must be called right after calculator creation, before first Increment,
+// as start time is moved to first sampled time. Ticker is released on Close.
+func (ec *Calculator) UseCoarseClock() {
+	ec.mu.Lock()
+	if ec.coarseClock {
+		ec.mu.Unlock()
+		return
+	}
+	ec.coarseClock = true
+
+	sharedClock.acquire()
+
+	// Sampled time may be behind start time taken by time.Now
+	if now := sharedClock.Now(); ec.processed == 0 && now.Before(ec.startTime) {
+		ec.startTime = now
+		ec.currentPeriod = now.Truncate(ec.periodDuration)
+		ec.loadsStart = now
+		ec.loads = newLoadAverages(now, ec.rateWindows())
+	}
+	ec.mu.Unlock()
+
+	ec.onClose(sharedClock.release)
+}
+
+// now returns current time according to calculator clock
+func (ec *Calculator) now() time.Time {
+	if ec.coarseClock {
+		return sharedClock.Now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package eta
+
+import (
+	"errors"
+	"os"
+)
+
+// EnableVirtualTerminal enables processing of ANSI escape sequences by console of f
+// and reports whether they are supported. Terminals on this platform always support them.
+func EnableVirtualTerminal(f *os.File) bool {
+	return true
+}
+
+// enableVirtualTerminal reports that ANSI escape sequences are supported,
+// console mode is not changed so there is nothing to restore
+func enableVirtualTerminal(f *os.File) (bool, func() error) {
+	return true, nil
+}
+
+// setConsoleTitle reports that console API is not available on this platform
+func setConsoleTitle(title string) error {
+	return errors.New("console title is not supported on this platform")
+}
@@ -0,0 +1,69 @@
+package eta
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const enableVirtualTerminalProcessing = 0x0004
+
+var (
+	kernel32             = syscall.NewLazyDLL("kernel32.dll")
+	procSetConsoleMode   = kernel32.NewProc("SetConsoleMode")
+	procSetConsoleTitleW = kernel32.NewProc("SetConsoleTitleW")
+)
+
+// EnableVirtualTerminal enables processing of ANSI escape sequences by console of f
+// and reports whether they are supported. Legacy consoles do not support them.
+func EnableVirtualTerminal(f *os.File) bool {
+	ok, _ := enableVirtualTerminal(f)
+
+	return ok
+}
+
+// enableVirtualTerminal enables processing of ANSI escape sequences by console of f,
+// reports whether they are supported and returns function restoring previous console mode
+func enableVirtualTerminal(f *os.File) (bool, func() error) {
+	handle := syscall.Handle(f.Fd())
+
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return false, nil
+	}
+
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true, nil
+	}
+
+	if err := setConsoleMode(handle, mode|enableVirtualTerminalProcessing); err != nil {
+		return false, nil
+	}
+
+	return true, func() error { return setConsoleMode(handle, mode) }
+}
+
+// setConsoleMode sets console mode using Windows API
+func setConsoleMode(handle syscall.Handle, mode uint32) error {
+	r, _, err := procSetConsoleMode.Call(uintptr(handle), uintptr(mode))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// setConsoleTitle sets console window title using Windows API
+func setConsoleTitle(title string) error {
+	p, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return err
+	}
+
+	r, _, err := procSetConsoleTitleW.Call(uintptr(unsafe.Pointer(p)))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
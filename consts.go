@@ -5,4 +5,26 @@ import "time"
 const (
 	defaultPeriodCount    = 10
 	defaultPeriodDuration = time.Minute
+
+	defaultTimeFormat = "15:04:05"
+
+	defaultVolatilityWindow = 10
+
+	defaultWarmUpVariation = 0.1
+
+	coarseClockResolution = 10 * time.Millisecond
+
+	defaultEmitInterval  = time.Second
+	defaultWatchInterval = time.Second
+
+	defaultWebhookRetries    = 3
+	defaultWebhookRetryDelay = time.Second
+	defaultWebhookTimeout    = 10 * time.Second
+	defaultFlushTimeout      = 10 * time.Second
+
+	defaultSDNotifyMargin = time.Minute
+
+	defaultKubernetesTimeout = 10 * time.Second
+
+	defaultRedisTimeout = 10 * time.Second
 )
@@ -0,0 +1,17 @@
+package eta
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns copy of ctx carrying calculator
+func NewContext(ctx context.Context, ec *Calculator) context.Context {
+	return context.WithValue(ctx, contextKey{}, ec)
+}
+
+// FromContext returns calculator carried by ctx or nil if there is none
+func FromContext(ctx context.Context) *Calculator {
+	ec, _ := ctx.Value(contextKey{}).(*Calculator)
+
+	return ec
+}
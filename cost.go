@@ -0,0 +1,42 @@
+package eta
+
+import "time"
+
+// cost returns cost to date and projected total cost, must be called with lock held
+func (ec *Calculator) cost(now time.Time, eta time.Time) (toDate, projected float64) {
+	elapsed := now.Sub(ec.startTime).Seconds()
+
+	toDate = float64(ec.processed)*ec.CostPerItem + elapsed*ec.CostPerSecond
+
+	// Processed count may exceed total count with AllowOverflow
+	total := ec.TotalCount
+	if ec.processed > total {
+		total = ec.processed
+	}
+
+	if eta.IsZero() {
+		if ec.CostPerSecond != 0 {
+			return toDate, 0
+		}
+
+		return toDate, float64(total) * ec.CostPerItem
+	}
+
+	projected = float64(total)*ec.CostPerItem + eta.Sub(ec.startTime).Seconds()*ec.CostPerSecond
+	if projected < toDate {
+		projected = toDate
+	}
+
+	return toDate, projected
+}
+
+// Cost returns cost of processing to date
+func (ec *Calculator) Cost() float64 {
+	return ec.peek().Cost
+}
+
+// ProjectedCost returns projected total cost of processing based on ETA,
+// zero if it cannot be projected yet
+func (ec *Calculator) ProjectedCost() float64 {
+	return ec.peek().ProjectedCost
+}
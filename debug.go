@@ -0,0 +1,74 @@
+package eta
+
+import (
+	"fmt"
+	"time"
+)
+
+// InvariantError represents violation of calculator internal invariant detected in debug mode
+type InvariantError struct {
+	Message string
+}
+
+func (e *InvariantError) Error() string {
+	return "eta: invariant violated: " + e.Message
+}
+
+// violation reports invariant violation to OnViolation or panics if it is not set
+func (ec *Calculator) violation(format string, args ...interface{}) {
+	err := &InvariantError{Message: fmt.Sprintf(format, args...)}
+
+	if ec.OnViolation == nil {
+		panic(err)
+	}
+
+	ec.OnViolation(err)
+}
+
+// verify checks calculator invariants in debug mode, must be called with lock held
+func (ec *Calculator) verify(now time.Time) {
+	if !ec.Debug {
+		return
+	}
+
+	if ec.processed < 0 {
+		ec.violation("negative processed count %d", ec.processed)
+	}
+
+	if ec.processed > ec.TotalCount && !ec.AllowOverflow {
+		ec.violation("processed count %d exceeds total count %d", ec.processed, ec.TotalCount)
+	}
+
+	if ec.failed < 0 {
+		ec.violation("negative failed count %d", ec.failed)
+	}
+
+	if len(ec.stats) > ec.PeriodCount {
+		ec.violation("%d periods stored, more than period count %d", len(ec.stats), ec.PeriodCount)
+	}
+
+	for i, processed := range ec.stats {
+		if processed < 0 {
+			ec.violation("negative processed count %d of period %d", processed, i)
+		}
+	}
+
+	if elapsed := now.Sub(ec.startTime); elapsed < 0 {
+		ec.violation("negative elapsed time %s", elapsed)
+	}
+}
+
+// verifyPeriod checks in debug mode that periods are monotonically increasing,
+// must be called with lock held
+func (ec *Calculator) verifyPeriod(period time.Time) {
+	if ec.Debug && period.Before(ec.currentPeriod) {
+		ec.violation("period %s is before current period %s", period, ec.currentPeriod)
+	}
+}
+
+// verifyCycleTime checks in debug mode that cycle time is not negative
+func (ec *Calculator) verifyCycleTime(cycleTime time.Duration) {
+	if ec.Debug && cycleTime < 0 {
+		ec.violation("negative cycle time %s", cycleTime)
+	}
+}
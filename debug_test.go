@@ -0,0 +1,49 @@
+package eta
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebugConcurrentIncrement(t *testing.T) {
+	for _, coarse := range []bool{false, true} {
+		ec := NewCustom(1<<30, time.Millisecond)
+		if coarse {
+			ec.UseCoarseClock()
+		}
+		ec.Debug = true
+
+		var violations int64
+		ec.OnViolation = func(err error) {
+			if atomic.AddInt64(&violations, 1) == 1 {
+				t.Error(err)
+			}
+		}
+
+		const goroutines, increments = 8, 20000
+
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < increments; j++ {
+					ec.Increment(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if violations > 0 {
+			t.Errorf("coarse clock %v: %d invariant violations", coarse, violations)
+		}
+
+		if got := ec.Snapshot().Processed; got != goroutines*increments {
+			t.Errorf("coarse clock %v: processed = %d, want %d", coarse, got, goroutines*increments)
+		}
+
+		ec.Close()
+	}
+}
@@ -0,0 +1,49 @@
+package eta
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DesktopNotifier represents notifier which shows desktop notifications
+// on completion and stall events
+type DesktopNotifier struct {
+	// Notification title
+	Title string
+
+	// Function showing notification, platform default is used if nil
+	Send func(title, message string) error
+}
+
+// NewDesktopNotifier returns new desktop notifier with platform default sending function
+func NewDesktopNotifier(title string) *DesktopNotifier {
+	return &DesktopNotifier{
+		Title: title,
+		Send:  sendDesktopNotification}
+}
+
+// Notify shows notification for completion and stall events, other events are ignored
+func (n *DesktopNotifier) Notify(ctx context.Context, e Event) error {
+	var message string
+
+	switch e.Type {
+	case EventCompletion:
+		message = fmt.Sprintf("Processed %d items in %s", e.Snapshot.Processed, e.Snapshot.Elapsed().Round(time.Second))
+	case EventStall:
+		message = fmt.Sprintf("Stalled at %d of %d (%.0f%%)", e.Snapshot.Processed, e.Snapshot.TotalCount, e.Snapshot.Percent())
+	default:
+		return nil
+	}
+
+	send := n.Send
+	if send == nil {
+		send = sendDesktopNotification
+	}
+
+	if err := send(n.Title, message); err != nil {
+		return fmt.Errorf("desktop notification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package eta
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// sendDesktopNotification shows notification using AppleScript
+func sendDesktopNotification(title, message string) error {
+	script := "display notification " + strconv.Quote(message) + " with title " + strconv.Quote(title)
+
+	return exec.Command("osascript", "-e", script).Run()
+}
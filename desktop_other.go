@@ -0,0 +1,10 @@
+//go:build !linux && !freebsd && !openbsd && !netbsd && !dragonfly && !darwin && !windows
+
+package eta
+
+import "errors"
+
+// sendDesktopNotification reports that desktop notifications are not supported
+func sendDesktopNotification(title, message string) error {
+	return errors.New("not supported on this platform")
+}
@@ -0,0 +1,10 @@
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package eta
+
+import "os/exec"
+
+// sendDesktopNotification shows notification using notify-send
+func sendDesktopNotification(title, message string) error {
+	return exec.Command("notify-send", title, message).Run()
+}
@@ -0,0 +1,25 @@
+package eta
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// sendDesktopNotification shows balloon tip notification using PowerShell.
+// Process is not waited for as it keeps tray icon alive while notification is shown.
+func sendDesktopNotification(title, message string) error {
+	script := `Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(10000, '` + quotePowerShell(title) + `', '` + quotePowerShell(message) + `', 'Info')
+Start-Sleep -Seconds 10
+$n.Dispose()`
+
+	return exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script).Start()
+}
+
+// quotePowerShell escapes s for single-quoted PowerShell string
+func quotePowerShell(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
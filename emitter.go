@@ -0,0 +1,54 @@
+package eta
+
+import (
+	"io"
+	"time"
+)
+
+// Emitter sends calculator snapshots to external system
+type Emitter interface {
+	Emit(s Snapshot) error
+}
+
+// EmitEvery starts goroutine which sends calculator snapshot to emitter every interval,
+// one second if interval is not positive.
+// Final snapshot is sent on Close, after that emitter is closed if it implements io.Closer.
+// Emit errors are passed to OnError. EmitEvery does nothing after Close.
+func (ec *Calculator) EmitEvery(e Emitter, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultEmitInterval
+	}
+
+	started := ec.spawn(func(done <-chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ec.handleError(e.Emit(ec.Snapshot()))
+			case <-done:
+				ec.handleError(e.Emit(ec.Snapshot()))
+				return
+			}
+		}
+	})
+	if !started {
+		return
+	}
+
+	if closer, ok := e.(io.Closer); ok {
+		ec.onClose(closer.Close)
+	}
+}
+
+// handleError passes non-nil error to OnError
+func (ec *Calculator) handleError(err error) {
+	if err == nil {
+		return
+	}
+
+	if ec.OnError != nil {
+		ec.OnError(err)
+	}
+}
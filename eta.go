@@ -1,14 +1,21 @@
 package eta
 
 import (
+	"math"
 	"sync"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
-// Calculator represents ETA calculator
+// Calculator represents ETA calculator.
+// Exported fields must be set before first use and before EmitEvery or Watch are called,
+// they are not guarded by calculator lock. TotalCount may be changed later only by AddItem.
 type Calculator struct {
 	startTime time.Time
 	processed int
+	failed    int
+	items     map[string]int
 
 	// Expected processing count
 	TotalCount int
@@ -16,12 +23,92 @@ type Calculator struct {
 	// Number of periods to store
 	PeriodCount int
 
+	// Weight ratio of each period to the next newer one used by Average.
+	// Values between 0 and 1 make contribution of old periods decay
+	// exponentially with age instead of cutting them off after PeriodCount periods,
+	// other values disable decay. Must be set before first Increment.
+	Decay            float64
+	decayedProcessed float64
+	decayedWeights   float64
+
+	// Function used by Custom to aggregate speeds of stored periods
+	Aggregator Aggregator
+	estimators map[string]Aggregator
+
+	// Duration of warm-up, items processed during warm-up are excluded from Eta
+	WarmUp time.Duration
+
+	// Number of last periods which must have stable processing speed to finish warm-up,
+	// zero value disables automatic warm-up detection, values above PeriodCount are
+	// limited to PeriodCount. Eta uses last period speed until warm-up is finished.
+	WarmUpPeriods int
+
+	// Maximum variation coefficient of processing speed of last WarmUpPeriods periods
+	// considered stable, 0.1 if not positive
+	WarmUpVariation float64
+	warmUp          warmUpState
+
+	// Number of last periods kept for RunReport history and estimator accuracy,
+	// zero value disables history
+	History int
+
+	// Number of last refreshes used by Volatility
+	VolatilityWindow int
+	history          etaHistory
+
+	limiter *rate.Limiter
+
+	// Backoff policy of failed items retries, nil disables retry tail modeling
+	RetryPolicy *RetryPolicy
+
+	// Cost of processing one item
+	CostPerItem float64
+
+	// Cost of one second of processing
+	CostPerSecond float64
+
+	// Time zone of reported times, nil value keeps times local
+	Location *time.Location
+
+	// Layout of ETA in progress descriptions, "15:04:05" by default
+	TimeFormat string
+
+	// Enables verification of internal invariants on every operation
+	Debug bool
+
+	// Allows processed count to exceed TotalCount in debug mode
+	AllowOverflow bool
+
+	// Function called on invariant violations in debug mode, panic is raised if nil.
+	// Must not call calculator methods.
+	OnViolation func(error)
+
+	// Function called on errors in background goroutines
+	OnError func(error)
+
+	coarseClock bool
+
 	periodDuration   time.Duration
 	currentPeriod    time.Time
 	currentProcessed int
 	stats            []int
+	firstPeriod      time.Duration // time covered by stats[0] if it is partial period of start
+
+	loads      []loadAverage
+	loadsStart time.Time
+
+	finishTime    time.Time
+	stalls        int
+	peakProcessed int
+	periods       []PeriodStat
+	samples       []estimateSample
 
 	mu sync.RWMutex
+
+	done    chan struct{} // created on demand to keep zero value usable
+	wg      sync.WaitGroup
+	closed  bool
+	closers []func() error
 }
 
 // New return new ETA calculator
@@ -49,53 +136,118 @@ func (ec *Calculator) Increment(n int) {
 		return
 	}
 
-	now := time.Now()
+	now := ec.now()
 
 	ec.mu.Lock()
 	defer ec.mu.Unlock()
 
+	ec.increment(now, n)
+}
+
+// increment increments processing count, must be called with lock held
+func (ec *Calculator) increment(now time.Time, n int) {
+	defer ec.verify(now)
+
+	ec.checkWarmUp(now)
+
 	ec.processed += n
+	ec.recordFinish(now)
+
+	for i := range ec.loads {
+		ec.loads[i].add(now, n)
+	}
 
 	// -------------------------------------------------------------------------
 	period := now.Truncate(ec.periodDuration)
 
+	// Time sampled before lock by concurrent caller may belong to already finished period
+	if period.Before(ec.currentPeriod) {
+		period = ec.currentPeriod
+	}
+	ec.verifyPeriod(period)
+
 	if ec.currentPeriod == period {
 		ec.currentProcessed += n
 		return
 	} else {
+		ec.recordPeriod(now, period)
+		ec.decayPeriod(period)
+		if len(ec.stats) == 0 && ec.startTime.After(ec.currentPeriod) {
+			ec.firstPeriod = ec.currentPeriod.Add(ec.periodDuration).Sub(ec.startTime)
+		}
 		ec.stats = append(ec.stats, ec.currentProcessed)
-		ec.currentProcessed = 0
+		ec.currentProcessed = n
 		ec.currentPeriod = period
 	}
 
 	if len(ec.stats) > ec.PeriodCount {
-		ec.stats = ec.stats[:ec.PeriodCount]
+		ec.stats = ec.stats[len(ec.stats)-ec.PeriodCount:]
+		ec.firstPeriod = 0
 	}
 }
 
 // Last returns ETA based on last period processing speed
 func (ec *Calculator) Last() time.Time {
-	if ec.processed == 0 {
-		return time.Time{}
-	}
+	now := ec.now()
 
 	ec.mu.RLock()
 	defer ec.mu.RUnlock()
 
+	return ec.in(ec.last(now))
+}
+
+// last returns ETA based on last period processing speed,
+// must be called with lock held
+func (ec *Calculator) last(now time.Time) time.Time {
+	if len(ec.stats) == 0 {
+		return ec.eta(now)
+	}
+
+	if ec.stats[len(ec.stats)-1] == 0 {
+		return time.Time{}
+	}
+
 	lastPeriodSpeed := ec.periodDuration / time.Duration(ec.stats[len(ec.stats)-1])
 
-	return time.Now().Add(lastPeriodSpeed * time.Duration(ec.TotalCount-ec.processed))
+	return ec.project(now, lastPeriodSpeed)
+}
+
+// project returns completion time of remaining items processed with given cycle time,
+// must be called with lock held
+func (ec *Calculator) project(now time.Time, cycleTime time.Duration) time.Time {
+	ec.verify(now)
+	ec.verifyCycleTime(cycleTime)
+
+	remaining := time.Duration(ec.TotalCount-ec.processed) * cycleTime
+	if limited := ec.limitedDuration(ec.TotalCount - ec.processed); remaining < limited {
+		remaining = limited
+	}
+
+	return now.Add(addDurations(remaining, ec.retryTail(cycleTime)))
 }
 
 // cycleTime returns cycle time based on total time and total processed items count
+// excluding items processed during warm-up
 func (ec *Calculator) cycleTime(now time.Time) time.Duration {
-	elapsedTime := time.Since(ec.startTime)
+	startTime, processed := ec.startTime, ec.processed
 
-	return elapsedTime / time.Duration(ec.processed)
+	if ec.warmUp.done && ec.processed > ec.warmUp.processed {
+		startTime, processed = ec.warmUp.time, ec.processed-ec.warmUp.processed
+	} else if ec.warmingUp() && len(ec.stats) > 0 && ec.stats[len(ec.stats)-1] > 0 {
+		return ec.periodDuration / time.Duration(ec.stats[len(ec.stats)-1])
+	}
+
+	elapsedTime := now.Sub(startTime)
+
+	return elapsedTime / time.Duration(processed)
 }
 
 // averageCycleTime returns cycle time based on average processing speed of last periods
 func (ec *Calculator) averageCycleTime() time.Duration {
+	if ec.Decay > 0 && ec.Decay < 1 {
+		return ec.decayedCycleTime()
+	}
+
 	processed := ec.stats[len(ec.stats)-1]
 	startPeriod := ec.currentPeriod.Add(-ec.periodDuration)
 
@@ -111,6 +263,33 @@ func (ec *Calculator) averageCycleTime() time.Duration {
 	return ec.currentPeriod.Sub(startPeriod) / time.Duration(processed)
 }
 
+// decayPeriod adds finished current period to decayed totals, older periods
+// including idle ones until new period are decayed, must be called with lock held
+func (ec *Calculator) decayPeriod(period time.Time) {
+	if ec.Decay <= 0 || ec.Decay >= 1 {
+		return
+	}
+
+	ec.decayedProcessed = ec.decayedProcessed*ec.Decay + float64(ec.currentProcessed)
+	ec.decayedWeights = ec.decayedWeights*ec.Decay + 1
+
+	if idle := float64(period.Sub(ec.currentPeriod)/ec.periodDuration - 1); idle > 0 {
+		decay := math.Pow(ec.Decay, idle)
+		ec.decayedProcessed *= decay
+		ec.decayedWeights = ec.decayedWeights*decay + (1-decay)/(1-ec.Decay)
+	}
+}
+
+// decayedCycleTime returns cycle time based on average processing speed of all periods
+// with contribution of each period decaying exponentially with its age
+func (ec *Calculator) decayedCycleTime() time.Duration {
+	if ec.decayedProcessed == 0 {
+		return time.Duration(0)
+	}
+
+	return time.Duration(ec.decayedWeights * float64(ec.periodDuration) / ec.decayedProcessed)
+}
+
 // optimisticCycleTime returns cycle time based on detected maximum of processing speed
 func (ec *Calculator) optimisticCycleTime() time.Duration {
 	var maxSpeed time.Duration
@@ -160,68 +339,103 @@ func (ec *Calculator) pessimisticCycleTime() time.Duration {
 	return minSpeed * time.Duration(1+nulPeriods)
 }
 
-// Eta returns ETA based on total time and total processed items count
+// Eta returns ETA based on total time and total processed items count.
+// Every call is counted as refresh by Volatility.
 func (ec *Calculator) Eta() time.Time {
-	if ec.processed == 0 {
-		return time.Time{}
-	}
+	now := ec.now()
 
 	ec.mu.RLock()
 	defer ec.mu.RUnlock()
 
-	now := time.Now()
+	eta := ec.in(ec.eta(now))
+	ec.recordEta(eta)
+
+	return eta
+}
+
+// eta returns ETA based on total time and total processed items count,
+// must be called with lock held
+func (ec *Calculator) eta(now time.Time) time.Time {
+	if ec.processed == 0 {
+		return time.Time{}
+	}
+
 	avgCycleTime := ec.cycleTime(now)
 
-	return now.Add(avgCycleTime * time.Duration(ec.TotalCount-ec.processed))
+	return ec.project(now, avgCycleTime)
 }
 
 // Average returns ETA based on average processing speed of last periods
 func (ec *Calculator) Average() time.Time {
-	if len(ec.stats) == 0 {
-		return ec.Eta()
-	}
+	now := ec.now()
 
 	ec.mu.RLock()
 	defer ec.mu.RUnlock()
 
+	return ec.in(ec.average(now))
+}
+
+// average returns ETA based on average processing speed of last periods,
+// must be called with lock held
+func (ec *Calculator) average(now time.Time) time.Time {
+	if len(ec.stats) == 0 {
+		return ec.eta(now)
+	}
+
 	avgCycleTime := ec.averageCycleTime()
 	if avgCycleTime == 0 {
 		return time.Time{}
 	}
 
-	return time.Now().Add(time.Duration(ec.TotalCount-ec.processed) * avgCycleTime)
+	return ec.project(now, avgCycleTime)
 }
 
 // Optimistic returns ETA based on detected maximum of processing speed
 func (ec *Calculator) Optimistic() time.Time {
-	if len(ec.stats) == 0 {
-		return ec.Eta()
-	}
+	now := ec.now()
 
 	ec.mu.RLock()
 	defer ec.mu.RUnlock()
 
+	return ec.in(ec.optimistic(now))
+}
+
+// optimistic returns ETA based on detected maximum of processing speed,
+// must be called with lock held
+func (ec *Calculator) optimistic(now time.Time) time.Time {
+	if len(ec.stats) == 0 {
+		return ec.eta(now)
+	}
+
 	optimisticCycleTime := ec.optimisticCycleTime()
 	if optimisticCycleTime == 0 {
 		return time.Time{}
 	}
 
-	return time.Now().Add(time.Duration(ec.TotalCount-ec.processed) * ec.optimisticCycleTime())
+	return ec.project(now, optimisticCycleTime)
 }
 
 // Pessimistic returns ETA based on detected minimum of processing speed
 func (ec *Calculator) Pessimistic() time.Time {
-	if len(ec.stats) == 0 {
-		return ec.Eta()
-	}
+	now := ec.now()
 
 	ec.mu.RLock()
 	defer ec.mu.RUnlock()
 
+	return ec.in(ec.pessimistic(now))
+}
+
+// pessimistic returns ETA based on detected minimum of processing speed,
+// must be called with lock held
+func (ec *Calculator) pessimistic(now time.Time) time.Time {
+	if len(ec.stats) == 0 {
+		return ec.eta(now)
+	}
+
 	pessimisticCycleTime := ec.pessimisticCycleTime()
 	if pessimisticCycleTime == 0 {
 		return time.Time{}
 	}
 
-	return time.Now().Add(time.Duration(ec.TotalCount-ec.processed) * pessimisticCycleTime)
+	return ec.project(now, pessimisticCycleTime)
 }
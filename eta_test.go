@@ -0,0 +1,93 @@
+package eta
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIncrementCountsRolloverItems(t *testing.T) {
+	ec := NewCustom(100, time.Minute)
+	start := ec.currentPeriod
+
+	ec.increment(start, 3)
+	ec.increment(start.Add(time.Minute), 5)
+
+	if ec.currentProcessed != 5 {
+		t.Errorf("current period processed = %d, want 5", ec.currentProcessed)
+	}
+
+	if want := []int{3}; !reflect.DeepEqual(ec.stats, want) {
+		t.Errorf("stats = %v, want %v", ec.stats, want)
+	}
+}
+
+func TestIncrementKeepsNewestPeriods(t *testing.T) {
+	ec := NewCustom(100, time.Minute)
+	ec.PeriodCount = 2
+	start := ec.currentPeriod
+
+	for i := 1; i <= 4; i++ {
+		ec.increment(start.Add(time.Duration(i)*time.Minute), i)
+	}
+
+	if want := []int{2, 3}; !reflect.DeepEqual(ec.stats, want) {
+		t.Errorf("stats = %v, want %v", ec.stats, want)
+	}
+}
+
+func TestDecayKeepsPeriodsBeyondWindow(t *testing.T) {
+	ec := NewCustom(100, time.Minute)
+	ec.PeriodCount = 2
+	ec.Decay = 0.5
+	start := ec.currentPeriod
+
+	for i, n := range []int{8, 4, 2, 1} {
+		ec.increment(start.Add(time.Duration(i)*time.Minute), n)
+	}
+
+	// Periods 8, 4 and 2 weighted 0.25, 0.5 and 1
+	if got, want := ec.averageCycleTime(), 17500*time.Millisecond; got != want {
+		t.Errorf("averageCycleTime() = %s, want %s", got, want)
+	}
+}
+
+func TestPeriodRatesPartialFirstPeriod(t *testing.T) {
+	ec := NewCustom(10000, time.Minute)
+	ec.startTime = ec.currentPeriod.Add(50 * time.Second)
+
+	for i := 0; i < 130; i++ {
+		ec.increment(ec.startTime.Add(time.Duration(i)*time.Second), 10)
+	}
+
+	if want := []int{100, 600}; !reflect.DeepEqual(ec.stats, want) {
+		t.Fatalf("stats = %v, want %v", ec.stats, want)
+	}
+
+	if got := ec.MinPeriodRate(); got != 10 {
+		t.Errorf("MinPeriodRate() = %v, want 10", got)
+	}
+}
+
+func TestAccessorsUseLocation(t *testing.T) {
+	ec := New(100)
+	defer ec.Close()
+
+	ec.Location = time.FixedZone("test", 3*60*60)
+	ec.AddEstimator("max", MaxRate)
+	ec.Increment(10)
+
+	for name, eta := range map[string]time.Time{
+		"Eta":         ec.Eta(),
+		"Last":        ec.Last(),
+		"Average":     ec.Average(),
+		"Optimistic":  ec.Optimistic(),
+		"Pessimistic": ec.Pessimistic(),
+		"Custom":      ec.Custom(),
+		"Estimate":    ec.Estimate("max"),
+	} {
+		if eta.Location() != ec.Location {
+			t.Errorf("%s() location = %v, want %v", name, eta.Location(), ec.Location)
+		}
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/nxshock/go-eta
 
 go 1.18
+
+require golang.org/x/time v0.5.0
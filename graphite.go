@@ -0,0 +1,88 @@
+package eta
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Graphite represents emitter which sends snapshot metrics to Graphite
+// using plaintext protocol
+type Graphite struct {
+	addr   string
+	prefix string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewGraphite returns new Graphite emitter sending metrics to TCP address addr.
+// Metric paths are prefixed with prefix.
+func NewGraphite(addr, prefix string) (*Graphite, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("graphite: %w", err)
+	}
+
+	return &Graphite{
+		addr:   addr,
+		prefix: prefix,
+		conn:   conn}, nil
+}
+
+// Emit sends processed, total, rate and remaining seconds metrics.
+// Connection is reestablished if previous write failed.
+func (g *Graphite) Emit(snapshot Snapshot) error {
+	var b bytes.Buffer
+
+	timestamp := snapshot.Time.Unix()
+	g.metric(&b, "processed", float64(snapshot.Processed), timestamp)
+	g.metric(&b, "total", float64(snapshot.TotalCount), timestamp)
+	g.metric(&b, "rate", snapshot.Rate, timestamp)
+	if !snapshot.Eta.IsZero() {
+		g.metric(&b, "remaining_seconds", snapshot.RemainingTime().Seconds(), timestamp)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.conn == nil {
+		conn, err := net.Dial("tcp", g.addr)
+		if err != nil {
+			return fmt.Errorf("graphite: %w", err)
+		}
+		g.conn = conn
+	}
+
+	if _, err := g.conn.Write(b.Bytes()); err != nil {
+		g.conn.Close()
+		g.conn = nil
+		return fmt.Errorf("graphite: %w", err)
+	}
+
+	return nil
+}
+
+func (g *Graphite) metric(b *bytes.Buffer, name string, value float64, timestamp int64) {
+	if g.prefix != "" {
+		name = g.prefix + "." + name
+	}
+
+	fmt.Fprintf(b, "%s %g %d\n", name, value, timestamp)
+}
+
+// Close closes connection
+func (g *Graphite) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.conn == nil {
+		return nil
+	}
+
+	err := g.conn.Close()
+	g.conn = nil
+
+	return err
+}
@@ -0,0 +1,50 @@
+package eta
+
+// AddItem registers pending item with its size or cost. Expected processing count
+// is increased by size, so all estimates are calculated against remaining weighted work
+// instead of remaining items count. Registering same item again replaces its size.
+// Items registered with AddItem must be marked processed with Complete instead of Increment.
+func (ec *Calculator) AddItem(id string, size int) {
+	if size < 0 {
+		return
+	}
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if ec.items == nil {
+		ec.items = make(map[string]int)
+	}
+
+	ec.TotalCount += size - ec.items[id]
+	ec.items[id] = size
+}
+
+// Complete marks registered item processed increasing processing count by its size.
+// Reports whether item was pending.
+func (ec *Calculator) Complete(id string) bool {
+	now := ec.now()
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	size, ok := ec.items[id]
+	if !ok {
+		return false
+	}
+	delete(ec.items, id)
+
+	if size > 0 {
+		ec.increment(now, size)
+	}
+
+	return true
+}
+
+// PendingItems returns count of registered items which are not completed yet
+func (ec *Calculator) PendingItems() int {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	return len(ec.items)
+}
@@ -0,0 +1,143 @@
+package eta
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	kubernetesServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	kubernetesAnnotationPrefix  = "eta.nxshock.github.com/"
+)
+
+// Kubernetes represents emitter which patches progress onto Kubernetes object
+// using in-cluster service account credentials
+type Kubernetes struct {
+	client *http.Client
+	url    string
+
+	// patch returns merge patch body for progress fields
+	patch func(fields map[string]string) interface{}
+}
+
+// NewKubernetesJob returns new emitter storing progress in annotations of Job.
+// Empty namespace means namespace of current pod.
+func NewKubernetesJob(namespace, name string) (*Kubernetes, error) {
+	k, err := newKubernetes(namespace, name, "/apis/batch/v1/namespaces/%s/jobs/%s")
+	if err != nil {
+		return nil, err
+	}
+
+	k.patch = func(fields map[string]string) interface{} {
+		annotations := make(map[string]string, len(fields))
+		for key, value := range fields {
+			annotations[kubernetesAnnotationPrefix+key] = value
+		}
+
+		return map[string]interface{}{"metadata": map[string]interface{}{"annotations": annotations}}
+	}
+
+	return k, nil
+}
+
+// NewKubernetesConfigMap returns new emitter storing progress in data of existing ConfigMap.
+// Empty namespace means namespace of current pod.
+func NewKubernetesConfigMap(namespace, name string) (*Kubernetes, error) {
+	k, err := newKubernetes(namespace, name, "/api/v1/namespaces/%s/configmaps/%s")
+	if err != nil {
+		return nil, err
+	}
+
+	k.patch = func(fields map[string]string) interface{} {
+		return map[string]interface{}{"data": fields}
+	}
+
+	return k, nil
+}
+
+// newKubernetes returns emitter for named object with API path format
+func newKubernetes(namespace, name, pathFormat string) (*Kubernetes, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("kubernetes: not running in cluster")
+	}
+
+	if namespace == "" {
+		b, err := os.ReadFile(kubernetesServiceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: %w", err)
+		}
+		namespace = strings.TrimSpace(string(b))
+	}
+
+	ca, err := os.ReadFile(kubernetesServiceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("kubernetes: invalid CA certificate")
+	}
+
+	return &Kubernetes{
+		client: &http.Client{
+			Timeout:   defaultKubernetesTimeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}},
+		url: "https://" + net.JoinHostPort(host, port) + fmt.Sprintf(pathFormat, url.PathEscape(namespace), url.PathEscape(name))}, nil
+}
+
+// Emit patches object with processed, total, percent, ETA and status fields
+func (k *Kubernetes) Emit(snapshot Snapshot) error {
+	fields := map[string]string{
+		"processed": strconv.Itoa(snapshot.Processed),
+		"total":     strconv.Itoa(snapshot.TotalCount),
+		"percent":   strconv.FormatFloat(snapshot.Percent(), 'f', 1, 64),
+		"status":    snapshot.String()}
+	if !snapshot.Eta.IsZero() {
+		fields["eta"] = snapshot.Eta.Format(time.RFC3339)
+	}
+
+	body, err := json.Marshal(k.patch(fields))
+	if err != nil {
+		return fmt.Errorf("kubernetes: %w", err)
+	}
+
+	// Token is read on every request as projected tokens are rotated
+	token, err := os.ReadFile(kubernetesServiceAccountDir + "/token")
+	if err != nil {
+		return fmt.Errorf("kubernetes: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, k.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("kubernetes: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("kubernetes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kubernetes: unexpected status %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
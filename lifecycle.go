@@ -0,0 +1,92 @@
+package eta
+
+import "context"
+
+// spawn starts goroutine owned by calculator and reports whether it was started,
+// nothing is started after Close. fn must return after done channel is closed.
+func (ec *Calculator) spawn(fn func(done <-chan struct{})) bool {
+	ec.mu.Lock()
+	if ec.closed {
+		ec.mu.Unlock()
+		return false
+	}
+	ec.wg.Add(1)
+	done := ec.doneChan()
+	ec.mu.Unlock()
+
+	go func() {
+		defer ec.wg.Done()
+		fn(done)
+	}()
+
+	return true
+}
+
+// doneChan returns channel closed on Close, must be called with lock held
+func (ec *Calculator) doneChan() chan struct{} {
+	if ec.done == nil {
+		ec.done = make(chan struct{})
+	}
+
+	return ec.done
+}
+
+// onClose registers function called on Close after all owned goroutines
+// are stopped. Functions are called in reverse order of registration.
+// If calculator is already closed, fn is called as soon as owned goroutines
+// are stopped and its error is passed to OnError.
+func (ec *Calculator) onClose(fn func() error) {
+	ec.mu.Lock()
+	closed := ec.closed
+	if !closed {
+		ec.closers = append(ec.closers, fn)
+	}
+	ec.mu.Unlock()
+
+	if closed {
+		ec.wg.Wait()
+		ec.handleError(fn())
+	}
+}
+
+// Close stops all goroutines owned by calculator and flushes emitters
+func (ec *Calculator) Close() error {
+	return ec.Shutdown(context.Background())
+}
+
+// Shutdown stops all goroutines owned by calculator and flushes emitters.
+// If ctx expires before goroutines are stopped, Shutdown returns ctx error.
+func (ec *Calculator) Shutdown(ctx context.Context) error {
+	ec.mu.Lock()
+	if !ec.closed {
+		ec.closed = true
+		close(ec.doneChan())
+	}
+	ec.mu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		ec.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	ec.mu.Lock()
+	closers := ec.closers
+	ec.closers = nil
+	ec.mu.Unlock()
+
+	var firstErr error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i](); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,75 @@
+package eta
+
+import (
+	"testing"
+	"time"
+)
+
+type closeCounter struct {
+	emits, closes int
+}
+
+func (c *closeCounter) Emit(Snapshot) error {
+	c.emits++
+	return nil
+}
+
+func (c *closeCounter) Close() error {
+	c.closes++
+	return nil
+}
+
+func TestZeroCalculatorClose(t *testing.T) {
+	var ec Calculator
+
+	if err := ec.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	if err := ec.Close(); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+}
+
+func TestCloseRunsLateClosers(t *testing.T) {
+	ec := New(10)
+	ec.Close()
+
+	var closed bool
+	ec.onClose(func() error {
+		closed = true
+		return nil
+	})
+
+	if !closed {
+		t.Error("onClose after Close did not call closer")
+	}
+}
+
+func TestEmitEveryAfterClose(t *testing.T) {
+	ec := New(10)
+	ec.Close()
+
+	emitter := new(closeCounter)
+	ec.EmitEvery(emitter, time.Millisecond)
+	ec.Close()
+
+	if emitter.emits != 0 || emitter.closes != 0 {
+		t.Errorf("emitter used after Close: %d emits, %d closes", emitter.emits, emitter.closes)
+	}
+}
+
+func TestEmitEveryFlushesOnClose(t *testing.T) {
+	ec := New(10)
+
+	emitter := new(closeCounter)
+	ec.EmitEvery(emitter, time.Hour)
+
+	if err := ec.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	if emitter.emits != 1 || emitter.closes != 1 {
+		t.Errorf("got %d emits and %d closes, want 1 and 1", emitter.emits, emitter.closes)
+	}
+}
@@ -0,0 +1,44 @@
+package eta
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// SetLimiter sets rate limiter governing processing loop.
+// Limit and burst of limiter are used as ceiling of processing speed in all projections,
+// zero limit makes projections of items above burst unbounded.
+// Limiter changes made by SetLimit and SetBurst are taken into account.
+func (ec *Calculator) SetLimiter(limiter *rate.Limiter) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.limiter = limiter
+}
+
+// limitedDuration returns minimum time needed to process remaining items
+// allowed by rate limiter, must be called with lock held
+func (ec *Calculator) limitedDuration(remaining int) time.Duration {
+	if ec.limiter == nil {
+		return 0
+	}
+
+	limit := ec.limiter.Limit()
+	if limit == rate.Inf {
+		return 0
+	}
+
+	limited := remaining - ec.limiter.Burst()
+	if limited <= 0 {
+		return 0
+	}
+
+	// Zero limit allows only burst events, so remaining items are never processed
+	if limit <= 0 {
+		return math.MaxInt64
+	}
+
+	return saturate(float64(limited) / float64(limit) * float64(time.Second))
+}
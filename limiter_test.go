@@ -0,0 +1,46 @@
+package eta
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestLimitedDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		limiter   *rate.Limiter
+		remaining int
+		want      time.Duration
+	}{
+		{"no limiter", nil, 100, 0},
+		{"infinite limit", rate.NewLimiter(rate.Inf, 1), 100, 0},
+		{"within burst", rate.NewLimiter(10, 5), 5, 0},
+		{"above burst", rate.NewLimiter(10, 5), 105, 10 * time.Second},
+		{"zero limit within burst", rate.NewLimiter(0, 5), 5, 0},
+		{"zero limit above burst", rate.NewLimiter(0, 5), 6, math.MaxInt64},
+		{"negative limit above burst", rate.NewLimiter(-1, 5), 6, math.MaxInt64},
+	}
+
+	for _, test := range tests {
+		ec := &Calculator{limiter: test.limiter}
+
+		if got := ec.limitedDuration(test.remaining); got != test.want {
+			t.Errorf("%s: limitedDuration(%d) = %v, want %v", test.name, test.remaining, got, test.want)
+		}
+	}
+}
+
+func TestLimiterZeroLimitEta(t *testing.T) {
+	ec := New(100)
+	defer ec.Close()
+
+	ec.SetLimiter(rate.NewLimiter(0, 1))
+	ec.Increment(1)
+
+	if eta := ec.Eta(); eta.Before(time.Now().AddDate(100, 0, 0)) {
+		t.Errorf("Eta() = %v, want unbounded projection", eta)
+	}
+}
@@ -0,0 +1,104 @@
+package eta
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// loadAverage represents exponentially decaying processed count over trailing window
+// like Unix load average
+type loadAverage struct {
+	window time.Duration
+	sum    float64
+	last   time.Time
+}
+
+// newLoadAverages returns load averages for windows starting at start
+func newLoadAverages(start time.Time, windows []time.Duration) []loadAverage {
+	loads := make([]loadAverage, len(windows))
+	for i, window := range windows {
+		loads[i] = loadAverage{window: window, last: start}
+	}
+
+	return loads
+}
+
+// add adds n processed items at moment now
+func (l *loadAverage) add(now time.Time, n int) {
+	l.sum = l.decayed(now) + float64(n)
+	l.last = now
+}
+
+// decayed returns decayed processed count at moment now
+func (l *loadAverage) decayed(now time.Time) float64 {
+	dt := now.Sub(l.last)
+	if dt <= 0 {
+		return l.sum
+	}
+
+	return l.sum * math.Exp(-float64(dt)/float64(l.window))
+}
+
+// rate returns processing speed in items per second, elapsed is time passed since
+// processing start and is used to correct underestimate while window is not filled
+func (l *loadAverage) rate(now time.Time, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	filled := 1 - math.Exp(-float64(elapsed)/float64(l.window))
+
+	return l.decayed(now) / (l.window.Seconds() * filled)
+}
+
+// SetRateWindows enables calculation of rates over trailing windows, e.g. 1, 5 and 15 minutes.
+// Rates are calculated from the current moment. Calling without windows disables calculation.
+// Windows are disabled by default as they add cost to every Increment.
+func (ec *Calculator) SetRateWindows(windows ...time.Duration) error {
+	for _, window := range windows {
+		if window <= 0 {
+			return fmt.Errorf("eta: non-positive rate window %s", window)
+		}
+	}
+
+	now := ec.now()
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.loads = newLoadAverages(now, windows)
+	ec.loadsStart = now
+
+	return nil
+}
+
+// WindowRates returns processing speeds in items per second over trailing windows
+// set by SetRateWindows, in same order. Returns nil if windows are not set.
+func (ec *Calculator) WindowRates() []float64 {
+	now := ec.now()
+
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	if len(ec.loads) == 0 {
+		return nil
+	}
+
+	rates := make([]float64, len(ec.loads))
+	for i := range ec.loads {
+		rates[i] = ec.loads[i].rate(now, now.Sub(ec.loadsStart))
+	}
+
+	return rates
+}
+
+// rateWindows returns trailing windows of load averages, must be called with lock held
+func (ec *Calculator) rateWindows() []time.Duration {
+	windows := make([]time.Duration, len(ec.loads))
+	for i := range ec.loads {
+		windows[i] = ec.loads[i].window
+	}
+
+	return windows
+}
@@ -0,0 +1,131 @@
+package eta
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RowQuerier represents database handle able to query single row,
+// implemented by *sql.DB, *sql.Conn and *sql.Tx
+type RowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Migration represents progress of database migration or backfill over multiple tables
+type Migration struct {
+	periodDuration time.Duration
+	overall        *Calculator
+
+	mu     sync.RWMutex
+	tables map[string]*Calculator
+	order  []string
+}
+
+// NewMigration returns new migration progress tracker
+func NewMigration() *Migration {
+	return NewCustomMigration(defaultPeriodDuration)
+}
+
+// NewCustomMigration returns new migration progress tracker with custom params
+func NewCustomMigration(periodDuration time.Duration) *Migration {
+	return &Migration{
+		periodDuration: periodDuration,
+		overall:        NewCustom(0, periodDuration),
+		tables:         make(map[string]*Calculator)}
+}
+
+// AddTable registers table with given rows count and returns its calculator.
+// Registering same table again replaces its rows count.
+func (m *Migration) AddTable(name string, rows int) *Calculator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ec, exists := m.tables[name]
+	if !exists {
+		ec = NewCustom(rows, m.periodDuration)
+		m.tables[name] = ec
+		m.order = append(m.order, name)
+		m.overall.addTotal(rows)
+		return ec
+	}
+
+	ec.mu.Lock()
+	m.overall.addTotal(rows - ec.TotalCount)
+	ec.TotalCount = rows
+	ec.mu.Unlock()
+
+	return ec
+}
+
+// CountTable registers table with rows count estimated by COUNT(*) query.
+// Table name is inserted into query as is, so it must be quoted by caller if needed.
+func (m *Migration) CountTable(ctx context.Context, db RowQuerier, name string) (*Calculator, error) {
+	var rows int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+name).Scan(&rows); err != nil {
+		return nil, fmt.Errorf("count rows of %s: %w", name, err)
+	}
+
+	return m.AddTable(name, rows), nil
+}
+
+// Increment increments processed rows count of table and overall progress
+func (m *Migration) Increment(name string, n int) {
+	ec := m.Table(name)
+	if ec == nil {
+		return
+	}
+
+	ec.Increment(n)
+	m.overall.Increment(n)
+}
+
+// Table returns calculator of registered table or nil if table is not registered
+func (m *Migration) Table(name string) *Calculator {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.tables[name]
+}
+
+// Tables returns names of registered tables in order of registration
+func (m *Migration) Tables() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]string(nil), m.order...)
+}
+
+// Overall returns calculator of overall progress
+func (m *Migration) Overall() *Calculator {
+	return m.overall
+}
+
+// Close closes all table calculators and overall calculator
+func (m *Migration) Close() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var firstErr error
+	for _, name := range m.order {
+		if err := m.tables[name].Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if err := m.overall.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+// addTotal adds n to expected processing count
+func (ec *Calculator) addTotal(n int) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.TotalCount += n
+}
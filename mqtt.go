@@ -0,0 +1,43 @@
+package eta
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MQTTClient represents MQTT client able to publish messages.
+// Clients with asynchronous publishing should wait for delivery and return its error.
+type MQTTClient interface {
+	Publish(topic string, qos byte, retained bool, payload []byte) error
+}
+
+// MQTTPublisher represents emitter which publishes JSON encoded snapshots to MQTT topic
+// as retained messages, so new subscribers receive last snapshot immediately
+type MQTTPublisher struct {
+	client MQTTClient
+	topic  string
+	qos    byte
+}
+
+// NewMQTTPublisher returns new emitter publishing snapshots to topic with given QoS.
+// Client is not disconnected by publisher.
+func NewMQTTPublisher(client MQTTClient, topic string, qos byte) *MQTTPublisher {
+	return &MQTTPublisher{
+		client: client,
+		topic:  topic,
+		qos:    qos}
+}
+
+// Emit publishes JSON encoded snapshot as retained message
+func (p *MQTTPublisher) Emit(snapshot Snapshot) error {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("mqtt: %w", err)
+	}
+
+	if err := p.client.Publish(p.topic, p.qos, true, payload); err != nil {
+		return fmt.Errorf("mqtt: %w", err)
+	}
+
+	return nil
+}
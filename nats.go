@@ -0,0 +1,39 @@
+package eta
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NATSConn represents NATS connection, implemented by *nats.Conn
+type NATSConn interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATSPublisher represents emitter which publishes JSON encoded snapshots to NATS subject
+type NATSPublisher struct {
+	conn    NATSConn
+	subject string
+}
+
+// NewNATSPublisher returns new emitter publishing snapshots to subject.
+// Connection is not closed by publisher.
+func NewNATSPublisher(conn NATSConn, subject string) *NATSPublisher {
+	return &NATSPublisher{
+		conn:    conn,
+		subject: subject}
+}
+
+// Emit publishes JSON encoded snapshot
+func (p *NATSPublisher) Emit(snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+
+	if err := p.conn.Publish(p.subject, data); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package eta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RedisClient represents Redis client able to publish messages and set keys
+type RedisClient interface {
+	Publish(ctx context.Context, channel string, message []byte) error
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// RedisPublisher represents emitter which publishes JSON encoded snapshots to Redis channel
+// and optionally stores last snapshot under key
+type RedisPublisher struct {
+	client  RedisClient
+	channel string
+
+	// Key to store last snapshot under, empty value disables storing
+	Key string
+
+	// Expiration of stored snapshot, zero value means no expiration
+	TTL time.Duration
+
+	// Time limit of single Emit including storing, 10 seconds if not positive
+	Timeout time.Duration
+}
+
+// NewRedisPublisher returns new emitter publishing snapshots to channel.
+// Client is not closed by publisher.
+func NewRedisPublisher(client RedisClient, channel string) *RedisPublisher {
+	return &RedisPublisher{
+		client:  client,
+		channel: channel}
+}
+
+// Emit publishes JSON encoded snapshot and stores it under Key if set
+func (p *RedisPublisher) Emit(snapshot Snapshot) error {
+	message, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultRedisTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := p.client.Publish(ctx, p.channel, message); err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+
+	if p.Key == "" {
+		return nil
+	}
+
+	if err := p.client.Set(ctx, p.Key, message, p.TTL); err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package eta
+
+import (
+	"sort"
+	"sync"
+)
+
+var registry = struct {
+	mu          sync.RWMutex
+	calculators map[string]*Calculator
+}{calculators: make(map[string]*Calculator)}
+
+// Register adds calculator to process-wide registry under name,
+// replacing previously registered one. Calculator is removed from registry on Close.
+func Register(name string, ec *Calculator) {
+	registry.mu.Lock()
+	registry.calculators[name] = ec
+	registry.mu.Unlock()
+
+	ec.onClose(func() error {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+
+		if registry.calculators[name] == ec {
+			delete(registry.calculators, name)
+		}
+
+		return nil
+	})
+}
+
+// Unregister removes calculator registered under name
+func Unregister(name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	delete(registry.calculators, name)
+}
+
+// Lookup returns calculator registered under name
+func Lookup(name string) (*Calculator, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	ec, ok := registry.calculators[name]
+
+	return ec, ok
+}
+
+// Names returns sorted names of registered calculators
+func Names() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	names := make([]string, 0, len(registry.calculators))
+	for name := range registry.calculators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
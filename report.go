@@ -0,0 +1,64 @@
+package eta
+
+import "time"
+
+// Report represents estimates of all strategies calculated from same calculator state
+type Report struct {
+	Snapshot
+
+	// ETA based on last period processing speed
+	Last time.Time `json:"last"`
+
+	// ETA based on average processing speed of last periods
+	Average time.Time `json:"average"`
+
+	// ETA based on detected maximum of processing speed
+	Optimistic time.Time `json:"optimistic"`
+
+	// ETA based on detected minimum of processing speed
+	Pessimistic time.Time `json:"pessimistic"`
+
+	// ETA based on processing speed calculated by Aggregator, nil if Aggregator is not set
+	Custom *time.Time `json:"custom,omitempty"`
+
+	// ETAs of estimators registered by AddEstimator
+	Estimators map[string]time.Time `json:"estimators,omitempty"`
+}
+
+// Report returns estimates of all strategies calculated under single lock.
+// Every call is counted as refresh by Volatility.
+func (ec *Calculator) Report() Report {
+	now := ec.now()
+
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	r := ec.report(now)
+	ec.recordEta(r.Eta)
+
+	return r
+}
+
+// report returns estimates of all strategies, must be called with lock held
+func (ec *Calculator) report(now time.Time) Report {
+	r := Report{
+		Snapshot:    ec.snapshot(now),
+		Last:        ec.in(ec.last(now)),
+		Average:     ec.in(ec.average(now)),
+		Optimistic:  ec.in(ec.optimistic(now)),
+		Pessimistic: ec.in(ec.pessimistic(now))}
+
+	if ec.Aggregator != nil {
+		custom := ec.in(ec.custom(now, ec.Aggregator))
+		r.Custom = &custom
+	}
+
+	if len(ec.estimators) > 0 {
+		r.Estimators = make(map[string]time.Time, len(ec.estimators))
+		for name, aggregator := range ec.estimators {
+			r.Estimators[name] = ec.in(ec.custom(now, aggregator))
+		}
+	}
+
+	return r
+}
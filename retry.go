@@ -0,0 +1,138 @@
+package eta
+
+import (
+	"math"
+	"time"
+)
+
+// RetryPolicy represents exponential backoff policy used for retries of failed items
+type RetryPolicy struct {
+	// Delay before first retry
+	InitialDelay time.Duration
+
+	// Delay multiplier applied on each next retry
+	Multiplier float64
+
+	// Maximum delay between retries, zero value means no limit
+	MaxDelay time.Duration
+
+	// Maximum number of retries, zero value means no limit
+	MaxAttempts int
+
+	// Probability of retry to succeed, zero value means every retry succeeds
+	SuccessProbability float64
+}
+
+// Fail marks n items as failed and pending retry
+func (ec *Calculator) Fail(n int) {
+	if n <= 0 {
+		return
+	}
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.failed += n
+
+	ec.verify(ec.now())
+}
+
+// Recover marks n previously failed items as successfully retried and processed
+func (ec *Calculator) Recover(n int) {
+	if n <= 0 {
+		return
+	}
+
+	now := ec.now()
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if n > ec.failed {
+		n = ec.failed
+	}
+	ec.failed -= n
+
+	ec.increment(now, n)
+}
+
+// retryTail returns expected extra time needed to retry pending failed items,
+// must be called with lock held
+func (ec *Calculator) retryTail(cycleTime time.Duration) time.Duration {
+	policy := ec.RetryPolicy
+	if policy == nil || ec.failed == 0 {
+		return 0
+	}
+
+	p := policy.SuccessProbability
+	if p <= 0 || p > 1 {
+		p = 1
+	}
+
+	// Number of retry rounds after which less than one item is expected to be still failing
+	rounds := 1.0
+	if p < 1 {
+		rounds = math.Floor(math.Log(float64(ec.failed))/-math.Log(1-p)) + 1
+	}
+	if policy.MaxAttempts > 0 {
+		rounds = math.Min(rounds, float64(policy.MaxAttempts))
+	}
+
+	// Expected retry attempts per item, first of them is already included in remaining count
+	attempts := 1.0
+	if p < 1 {
+		attempts = (1 - math.Pow(1-p, rounds)) / p
+	}
+	extraAttempts := float64(ec.failed) * (attempts - 1)
+
+	delays := backoffSum(float64(policy.InitialDelay), policy.Multiplier, float64(policy.MaxDelay), rounds)
+
+	return saturate(delays + extraAttempts*float64(cycleTime))
+}
+
+// backoffSum returns total delay of given number of retry rounds
+func backoffSum(initial, multiplier, max, rounds float64) float64 {
+	if initial <= 0 {
+		return 0
+	}
+
+	if max > 0 && initial >= max {
+		return max * rounds
+	}
+
+	if multiplier <= 0 || multiplier == 1 {
+		return initial * rounds
+	}
+
+	// Rounds before delay reaches max
+	uncapped := rounds
+	if max > 0 && multiplier > 1 {
+		uncapped = math.Min(rounds, math.Floor(math.Log(max/initial)/math.Log(multiplier))+1)
+	}
+
+	sum := initial * (math.Pow(multiplier, uncapped) - 1) / (multiplier - 1)
+
+	return sum + (rounds-uncapped)*max
+}
+
+// saturate converts nanoseconds to duration limiting it to maximum duration
+func saturate(ns float64) time.Duration {
+	if !(ns < math.MaxInt64) {
+		return math.MaxInt64
+	}
+
+	if ns < 0 {
+		return 0
+	}
+
+	return time.Duration(ns)
+}
+
+// addDurations returns sum of non-negative durations limited to maximum duration
+func addDurations(a, b time.Duration) time.Duration {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+
+	return a + b
+}
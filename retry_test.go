@@ -0,0 +1,52 @@
+package eta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryTail(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   time.Duration
+	}{
+		{"single round", RetryPolicy{InitialDelay: time.Second, Multiplier: 2}, time.Second},
+		{"exponential", RetryPolicy{InitialDelay: time.Second, Multiplier: 2, SuccessProbability: 0.5}, 10 * time.Second},
+		{"max delay", RetryPolicy{InitialDelay: time.Second, Multiplier: 2, MaxDelay: 3 * time.Second, SuccessProbability: 0.5}, 9 * time.Second},
+		{"max attempts", RetryPolicy{InitialDelay: time.Second, Multiplier: 2, MaxAttempts: 2, SuccessProbability: 0.5}, 5 * time.Second},
+		{"constant", RetryPolicy{InitialDelay: time.Second, SuccessProbability: 0.5}, 6 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tt.policy
+			ec := New(100)
+			ec.RetryPolicy = &policy
+			ec.Fail(4)
+
+			// 4 failed items with success probability 0.5 need 3 rounds
+			// and 0.75 extra attempts per item processed in 1s each
+			if got := ec.retryTail(time.Second); got != tt.want {
+				t.Errorf("retryTail() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryTailSaturates(t *testing.T) {
+	ec := New(2000)
+	ec.RetryPolicy = &RetryPolicy{InitialDelay: time.Second, Multiplier: 2, SuccessProbability: 0.1}
+	ec.Increment(1)
+	ec.Fail(1000)
+
+	now := time.Now()
+	if eta := ec.Eta(); eta.Before(now) {
+		t.Errorf("Eta() = %s, want time after %s", eta, now)
+	}
+
+	ec.RetryPolicy.SuccessProbability = 1e-12
+	if eta := ec.Eta(); eta.Before(now) {
+		t.Errorf("Eta() with tiny success probability = %s, want time after %s", eta, now)
+	}
+}
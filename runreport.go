@@ -0,0 +1,169 @@
+package eta
+
+import (
+	"math"
+	"time"
+)
+
+// PeriodStat represents processed items count of one period
+type PeriodStat struct {
+	Start     time.Time `json:"start"`
+	Processed int       `json:"processed"`
+}
+
+// RunReport represents summary of processing run
+type RunReport struct {
+	StartTime  time.Time     `json:"startTime"`
+	FinishTime time.Time     `json:"finishTime"`
+	Duration   time.Duration `json:"duration"`
+
+	// Processed items count
+	Processed int `json:"processed"`
+
+	// Expected processing count
+	TotalCount int `json:"totalCount"`
+
+	// Average processing speed in items per second
+	AverageRate float64 `json:"averageRate"`
+
+	// Maximum processing speed of single period in items per second
+	PeakRate float64 `json:"peakRate"`
+
+	// Number of times no items were processed for at least one whole period
+	Stalls int `json:"stalls"`
+
+	// Processed items count of last History periods of run
+	Periods []PeriodStat `json:"periods,omitempty"`
+
+	// Mean absolute error of each built-in estimator relative to actual remaining time
+	// sampled at start of last History periods, lower is better. Set only for finished runs.
+	Accuracy map[string]float64 `json:"accuracy,omitempty"`
+}
+
+// estimateSample represents estimates of built-in strategies at some moment
+type estimateSample struct {
+	time        time.Time
+	eta         time.Time
+	last        time.Time
+	average     time.Time
+	optimistic  time.Time
+	pessimistic time.Time
+}
+
+// recordPeriod stores finished current period statistics and, if History is enabled,
+// period and estimates at start of new period, must be called with lock held
+// before current period is replaced
+func (ec *Calculator) recordPeriod(now, period time.Time) {
+	if idle := period.Sub(ec.currentPeriod)/ec.periodDuration - 1; idle > 0 {
+		ec.stalls++
+	}
+
+	if ec.currentProcessed > ec.peakProcessed {
+		ec.peakProcessed = ec.currentProcessed
+	}
+
+	if ec.History <= 0 {
+		return
+	}
+
+	ec.periods = append(ec.periods, PeriodStat{Start: ec.currentPeriod, Processed: ec.currentProcessed})
+	if len(ec.periods) > ec.History {
+		ec.periods = ec.periods[len(ec.periods)-ec.History:]
+	}
+
+	ec.samples = append(ec.samples, estimateSample{
+		time:        now,
+		eta:         ec.eta(now),
+		last:        ec.last(now),
+		average:     ec.average(now),
+		optimistic:  ec.optimistic(now),
+		pessimistic: ec.pessimistic(now)})
+	if len(ec.samples) > ec.History {
+		ec.samples = ec.samples[len(ec.samples)-ec.History:]
+	}
+}
+
+// recordFinish stores moment when expected processing count is reached,
+// must be called with lock held
+func (ec *Calculator) recordFinish(now time.Time) {
+	if ec.finishTime.IsZero() && ec.TotalCount > 0 && ec.processed >= ec.TotalCount {
+		ec.finishTime = now
+	}
+}
+
+// RunReport returns summary of processing run. Unfinished run is summarized up to now.
+// Periods and Accuracy are filled only if History is enabled.
+func (ec *Calculator) RunReport() RunReport {
+	now := ec.now()
+
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	finishTime := ec.finishTime
+	if finishTime.IsZero() {
+		finishTime = now
+	}
+
+	r := RunReport{
+		StartTime:  ec.in(ec.startTime),
+		FinishTime: ec.in(finishTime),
+		Duration:   finishTime.Sub(ec.startTime),
+		Processed:  ec.processed,
+		TotalCount: ec.TotalCount,
+		PeakRate:   float64(ec.peakProcessed) / ec.periodDuration.Seconds(),
+		Stalls:     ec.stalls}
+
+	if r.Duration > 0 {
+		r.AverageRate = float64(ec.processed) / r.Duration.Seconds()
+	}
+
+	if ec.peakProcessed == 0 {
+		r.PeakRate = r.AverageRate
+	}
+
+	if ec.History > 0 {
+		r.Periods = append(append([]PeriodStat(nil), ec.periods...), PeriodStat{Start: ec.currentPeriod, Processed: ec.currentProcessed})
+	}
+
+	if !ec.finishTime.IsZero() {
+		r.Accuracy = ec.accuracy()
+	}
+
+	return r
+}
+
+// accuracy returns mean absolute error of each estimator relative to actual remaining time,
+// must be called with lock held
+func (ec *Calculator) accuracy() map[string]float64 {
+	var sums [5]float64
+	var counts [5]int
+
+	for _, sample := range ec.samples {
+		actual := ec.finishTime.Sub(sample.time)
+		if actual <= 0 {
+			continue
+		}
+
+		for i, eta := range [5]time.Time{sample.eta, sample.last, sample.average, sample.optimistic, sample.pessimistic} {
+			if eta.IsZero() {
+				continue
+			}
+
+			sums[i] += math.Abs(float64(eta.Sub(ec.finishTime))) / float64(actual)
+			counts[i]++
+		}
+	}
+
+	accuracy := make(map[string]float64)
+	for i, name := range [5]string{"eta", "last", "average", "optimistic", "pessimistic"} {
+		if counts[i] > 0 {
+			accuracy[name] = sums[i] / float64(counts[i])
+		}
+	}
+
+	if len(accuracy) == 0 {
+		return nil
+	}
+
+	return accuracy
+}
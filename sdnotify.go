@@ -0,0 +1,66 @@
+package eta
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// SDNotify represents emitter which reports progress to systemd using sd_notify protocol.
+// Service start timeout is extended by remaining time, status is set to progress description.
+type SDNotify struct {
+	conn *net.UnixConn
+
+	// Extra time added to remaining time on timeout extension
+	Margin time.Duration
+}
+
+// NewSDNotify returns new sd_notify emitter connected to socket from NOTIFY_SOCKET
+// environment variable. If variable is not set, emitter does nothing.
+func NewSDNotify() (*SDNotify, error) {
+	n := &SDNotify{Margin: defaultSDNotifyMargin}
+
+	name := os.Getenv("NOTIFY_SOCKET")
+	if name == "" {
+		return n, nil
+	}
+
+	if strings.HasPrefix(name, "@") {
+		name = "\x00" + name[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: name, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("sd_notify: %w", err)
+	}
+	n.conn = conn
+
+	return n, nil
+}
+
+// Emit sends STATUS and EXTEND_TIMEOUT_USEC updates
+func (n *SDNotify) Emit(snapshot Snapshot) error {
+	if n.conn == nil {
+		return nil
+	}
+
+	extend := snapshot.RemainingTime() + n.Margin
+	state := fmt.Sprintf("STATUS=%s\nEXTEND_TIMEOUT_USEC=%d", snapshot, extend.Microseconds())
+
+	if _, err := n.conn.Write([]byte(state)); err != nil {
+		return fmt.Errorf("sd_notify: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes socket connection
+func (n *SDNotify) Close() error {
+	if n.conn == nil {
+		return nil
+	}
+
+	return n.conn.Close()
+}
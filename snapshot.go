@@ -0,0 +1,179 @@
+package eta
+
+import (
+	"fmt"
+	"time"
+)
+
+// Snapshot represents calculator state at some moment
+type Snapshot struct {
+	// Moment of snapshot
+	Time time.Time `json:"time"`
+
+	// Processing start time
+	StartTime time.Time `json:"startTime"`
+
+	// Processed items count
+	Processed int `json:"processed"`
+
+	// Expected processing count
+	TotalCount int `json:"totalCount"`
+
+	// Processing speed in items per second
+	Rate float64 `json:"rate"`
+
+	// ETA based on total time and total processed items count
+	Eta time.Time `json:"eta"`
+
+	// Cost of processing to date
+	Cost float64 `json:"cost,omitempty"`
+
+	// Projected total cost of processing
+	ProjectedCost float64 `json:"projectedCost,omitempty"`
+
+	// Layout of ETA in progress description
+	timeFormat string
+}
+
+// Remaining returns count of items left to process
+func (s Snapshot) Remaining() int {
+	if s.Processed >= s.TotalCount {
+		return 0
+	}
+
+	return s.TotalCount - s.Processed
+}
+
+// Elapsed returns time passed since processing start
+func (s Snapshot) Elapsed() time.Duration {
+	return s.Time.Sub(s.StartTime)
+}
+
+// RemainingTime returns time left until ETA
+func (s Snapshot) RemainingTime() time.Duration {
+	if s.Eta.IsZero() || s.Eta.Before(s.Time) {
+		return 0
+	}
+
+	return s.Eta.Sub(s.Time)
+}
+
+// Percent returns processed part of total count in percents
+func (s Snapshot) Percent() float64 {
+	if s.TotalCount <= 0 {
+		return 0
+	}
+
+	return float64(s.Processed) / float64(s.TotalCount) * 100
+}
+
+// Snapshot returns current calculator state.
+// Every call is counted as refresh by Volatility.
+func (ec *Calculator) Snapshot() Snapshot {
+	s := ec.peek()
+	ec.recordEta(s.Eta)
+
+	return s
+}
+
+// peek returns current calculator state without counting it as refresh
+func (ec *Calculator) peek() Snapshot {
+	now := ec.now()
+
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	return ec.snapshot(now)
+}
+
+// IncrementAndSnapshot increments processing count and returns updated
+// calculator state taking lock only once. Every call is counted as refresh by Volatility.
+func (ec *Calculator) IncrementAndSnapshot(n int) Snapshot {
+	now := ec.now()
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if n > 0 {
+		ec.increment(now, n)
+	}
+
+	s := ec.snapshot(now)
+	ec.recordEta(s.Eta)
+
+	return s
+}
+
+// snapshot returns calculator state, must be called with lock held
+func (ec *Calculator) snapshot(now time.Time) Snapshot {
+	s := Snapshot{
+		Time:       now,
+		StartTime:  ec.startTime,
+		Processed:  ec.processed,
+		TotalCount: ec.TotalCount}
+
+	if elapsed := now.Sub(ec.startTime); elapsed > 0 {
+		s.Rate = float64(ec.processed) / elapsed.Seconds()
+	}
+
+	s.Eta = ec.eta(now)
+
+	s.Cost, s.ProjectedCost = ec.cost(now, s.Eta)
+
+	s.Time, s.StartTime, s.Eta = ec.in(s.Time), ec.in(s.StartTime), ec.in(s.Eta)
+	s.timeFormat = ec.TimeFormat
+
+	return s
+}
+
+// String returns human readable progress description
+func (s Snapshot) String() string {
+	if s.Eta.IsZero() {
+		return fmt.Sprintf("Processed %d of %d (%.0f%%)", s.Processed, s.TotalCount, s.Percent())
+	}
+
+	return fmt.Sprintf("Processed %d of %d (%.0f%%), ETA: %s", s.Processed, s.TotalCount, s.Percent(), s.FormatEta())
+}
+
+// FormatEta returns ETA formatted with calculator TimeFormat, empty string if ETA is unknown
+func (s Snapshot) FormatEta() string {
+	if s.Eta.IsZero() {
+		return ""
+	}
+
+	layout := s.timeFormat
+	if layout == "" {
+		layout = defaultTimeFormat
+	}
+
+	return s.Eta.Format(layout)
+}
+
+// FinishAtIn returns ETA in time zone loc
+func (s Snapshot) FinishAtIn(loc *time.Location) time.Time {
+	if s.Eta.IsZero() {
+		return s.Eta
+	}
+
+	return s.Eta.In(loc)
+}
+
+// String returns human readable description of current progress.
+// Every call is counted as refresh by Volatility.
+func (ec *Calculator) String() string {
+	return ec.Snapshot().String()
+}
+
+// FinishAtIn returns ETA based on total time and total processed items count in time zone loc
+func (ec *Calculator) FinishAtIn(loc *time.Location) time.Time {
+	return ec.peek().FinishAtIn(loc)
+}
+
+// in returns t in calculator Location, must be called with lock held
+func (ec *Calculator) in(t time.Time) time.Time {
+	if ec.Location == nil || t.IsZero() {
+		return t
+	}
+
+	return t.In(ec.Location)
+}
@@ -0,0 +1,65 @@
+package eta
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// StatsD represents emitter which sends snapshot gauges to StatsD/DogStatsD server
+type StatsD struct {
+	conn   net.Conn
+	prefix string
+	tags   string
+}
+
+// NewStatsD returns new StatsD emitter sending metrics to UDP address addr.
+// Metric names are prefixed with prefix, tags are sent in DogStatsD format.
+func NewStatsD(addr, prefix string, tags ...string) (*StatsD, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("statsd: %w", err)
+	}
+
+	s := &StatsD{
+		conn:   conn,
+		prefix: prefix}
+
+	if len(tags) > 0 {
+		s.tags = "|#" + strings.Join(tags, ",")
+	}
+
+	return s, nil
+}
+
+// Emit sends processed, total, rate and remaining seconds gauges
+func (s *StatsD) Emit(snapshot Snapshot) error {
+	var b bytes.Buffer
+
+	s.gauge(&b, "processed", float64(snapshot.Processed))
+	s.gauge(&b, "total", float64(snapshot.TotalCount))
+	s.gauge(&b, "rate", snapshot.Rate)
+	if !snapshot.Eta.IsZero() {
+		s.gauge(&b, "remaining_seconds", snapshot.RemainingTime().Seconds())
+	}
+
+	if _, err := s.conn.Write(bytes.TrimSuffix(b.Bytes(), []byte("\n"))); err != nil {
+		return fmt.Errorf("statsd: %w", err)
+	}
+
+	return nil
+}
+
+func (s *StatsD) gauge(b *bytes.Buffer, name string, value float64) {
+	if s.prefix != "" {
+		name = s.prefix + "." + name
+	}
+
+	fmt.Fprintf(b, "%s:%g|g%s\n", name, value, s.tags)
+}
+
+// Close closes connection
+func (s *StatsD) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,68 @@
+package eta
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// TerminalTitle represents emitter which shows percent and ETA in terminal title
+// using OSC escape sequence, or console API on legacy Windows consoles
+type TerminalTitle struct {
+	w    io.Writer
+	ansi bool
+
+	// restoreMode restores console mode changed to enable ANSI escape sequences
+	restoreMode func() error
+
+	// Text shown in title before progress
+	Prefix string
+}
+
+// NewTerminalTitle returns new emitter writing title updates to w, usually os.Stderr
+func NewTerminalTitle(w io.Writer) *TerminalTitle {
+	t := &TerminalTitle{w: w, ansi: true}
+
+	if f, ok := w.(*os.File); ok {
+		t.ansi, t.restoreMode = enableVirtualTerminal(f)
+	}
+
+	return t
+}
+
+// Emit sets terminal title to processed percent and ETA
+func (t *TerminalTitle) Emit(snapshot Snapshot) error {
+	title := fmt.Sprintf("%.0f%%", snapshot.Percent())
+	if !snapshot.Eta.IsZero() {
+		title += " ETA " + snapshot.FormatEta()
+	}
+
+	if t.Prefix != "" {
+		title = t.Prefix + " " + title
+	}
+
+	return t.set(title)
+}
+
+// Close resets terminal title and restores console mode
+func (t *TerminalTitle) Close() error {
+	err := t.set("")
+
+	if t.restoreMode != nil {
+		if restoreErr := t.restoreMode(); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}
+
+	return err
+}
+
+func (t *TerminalTitle) set(title string) error {
+	if !t.ansi {
+		return setConsoleTitle(title)
+	}
+
+	_, err := fmt.Fprintf(t.w, "\x1b]0;%s\x07", title)
+
+	return err
+}
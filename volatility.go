@@ -0,0 +1,77 @@
+package eta
+
+import (
+	"sync"
+	"time"
+)
+
+// etaHistory represents ETAs reported on last refreshes
+type etaHistory struct {
+	mu   sync.Mutex
+	etas []time.Time
+}
+
+// recordEta stores ETA returned to caller on refresh
+func (ec *Calculator) recordEta(eta time.Time) {
+	if eta.IsZero() {
+		return
+	}
+
+	window := ec.VolatilityWindow
+	if window <= 0 {
+		window = defaultVolatilityWindow
+	}
+
+	ec.history.mu.Lock()
+	defer ec.history.mu.Unlock()
+
+	ec.history.etas = append(ec.history.etas, eta)
+	if len(ec.history.etas) > window {
+		ec.history.etas = ec.history.etas[len(ec.history.etas)-window:]
+	}
+}
+
+// EtaSpread returns difference between latest and earliest of ETAs
+// returned by last VolatilityWindow refreshes
+func (ec *Calculator) EtaSpread() time.Duration {
+	ec.history.mu.Lock()
+	defer ec.history.mu.Unlock()
+
+	if len(ec.history.etas) == 0 {
+		return 0
+	}
+
+	min, max := ec.history.etas[0], ec.history.etas[0]
+	for _, eta := range ec.history.etas[1:] {
+		if eta.Before(min) {
+			min = eta
+		}
+		if eta.After(max) {
+			max = eta
+		}
+	}
+
+	return max.Sub(min)
+}
+
+// Volatility returns spread of ETAs returned by last refreshes relative to current remaining time.
+// Calls of Eta, Snapshot, IncrementAndSnapshot, Report and String are counted as refreshes,
+// including snapshots sent by EmitEvery and Watch.
+// Zero means stable estimate, values about 1 and above mean estimate is thrashing.
+func (ec *Calculator) Volatility() float64 {
+	spread := ec.EtaSpread()
+	if spread == 0 {
+		return 0
+	}
+
+	ec.history.mu.Lock()
+	latest := ec.history.etas[len(ec.history.etas)-1]
+	ec.history.mu.Unlock()
+
+	remaining := latest.Sub(ec.now())
+	if remaining <= 0 {
+		return 0
+	}
+
+	return float64(spread) / float64(remaining)
+}
@@ -0,0 +1,25 @@
+package eta
+
+import "testing"
+
+func TestVolatilityRefreshes(t *testing.T) {
+	ec := New(100)
+	defer ec.Close()
+
+	ec.Increment(10)
+
+	ec.Bucket()
+	ec.Cost()
+	if n := len(ec.history.etas); n != 0 {
+		t.Fatalf("%d refreshes recorded by Bucket and Cost, want 0", n)
+	}
+
+	ec.Eta()
+	ec.Snapshot()
+	ec.IncrementAndSnapshot(1)
+	ec.Report()
+	_ = ec.String()
+	if n := len(ec.history.etas); n != 5 {
+		t.Errorf("%d refreshes recorded, want 5", n)
+	}
+}
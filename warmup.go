@@ -0,0 +1,85 @@
+package eta
+
+import (
+	"math"
+	"time"
+)
+
+// warmUpState represents moment warm-up finished at
+type warmUpState struct {
+	done      bool
+	time      time.Time
+	processed int
+}
+
+// warmingUp reports whether warm-up is configured and not finished yet,
+// must be called with lock held
+func (ec *Calculator) warmingUp() bool {
+	return !ec.warmUp.done && (ec.WarmUp > 0 || ec.WarmUpPeriods > 0)
+}
+
+// checkWarmUp finishes warm-up if its conditions are met,
+// must be called with lock held before processed count is incremented
+func (ec *Calculator) checkWarmUp(now time.Time) {
+	if !ec.warmingUp() {
+		return
+	}
+
+	if ec.WarmUp > 0 && now.Sub(ec.startTime) < ec.WarmUp {
+		return
+	}
+
+	if ec.WarmUpPeriods > 0 && !ec.rateStable() {
+		return
+	}
+
+	ec.warmUp = warmUpState{
+		done:      true,
+		time:      now,
+		processed: ec.processed}
+}
+
+// rateStable reports whether variation coefficient of last WarmUpPeriods periods
+// (at most PeriodCount) does not exceed WarmUpVariation, must be called with lock held
+func (ec *Calculator) rateStable() bool {
+	count := ec.WarmUpPeriods
+	if count > ec.PeriodCount {
+		count = ec.PeriodCount
+	}
+
+	if count <= 0 || len(ec.stats) < count {
+		return false
+	}
+
+	periods := ec.stats[len(ec.stats)-count:]
+
+	var sum float64
+	for _, processed := range periods {
+		if processed == 0 {
+			return false
+		}
+		sum += float64(processed)
+	}
+	mean := sum / float64(len(periods))
+
+	var variance float64
+	for _, processed := range periods {
+		variance += (float64(processed) - mean) * (float64(processed) - mean)
+	}
+	variance /= float64(len(periods))
+
+	threshold := ec.WarmUpVariation
+	if threshold <= 0 {
+		threshold = defaultWarmUpVariation
+	}
+
+	return math.Sqrt(variance)/mean <= threshold
+}
+
+// WarmedUp reports whether warm-up is finished or not configured
+func (ec *Calculator) WarmedUp() bool {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	return !ec.warmingUp()
+}
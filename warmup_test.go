@@ -0,0 +1,52 @@
+package eta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWarmUpFixed(t *testing.T) {
+	ec := NewCustom(1000, time.Minute)
+	defer ec.Close()
+
+	ec.WarmUp = 2 * time.Minute
+
+	ec.increment(ec.startTime, 100)
+	ec.increment(ec.startTime.Add(3*time.Minute), 10)
+	ec.increment(ec.startTime.Add(4*time.Minute), 10)
+
+	if !ec.WarmedUp() {
+		t.Fatal("WarmedUp() = false, want true")
+	}
+
+	if got, want := ec.cycleTime(ec.startTime.Add(5*time.Minute)), 6*time.Second; got != want {
+		t.Errorf("cycleTime() = %v, want %v", got, want)
+	}
+}
+
+func TestWarmUpPeriods(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   []int
+		warmedUp bool
+	}{
+		{"stable", []int{10, 10, 10, 10, 10, 10}, true},
+		{"unstable", []int{1, 100, 1, 100, 1, 100}, false},
+	}
+
+	for _, test := range tests {
+		ec := NewCustom(1000, time.Minute)
+		ec.PeriodCount = 3
+		ec.WarmUpPeriods = 50
+
+		for _, n := range test.counts {
+			ec.increment(ec.currentPeriod.Add(time.Minute), n)
+		}
+
+		if got := ec.WarmedUp(); got != test.warmedUp {
+			t.Errorf("%s: WarmedUp() = %v, want %v", test.name, got, test.warmedUp)
+		}
+
+		ec.Close()
+	}
+}
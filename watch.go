@@ -0,0 +1,155 @@
+package eta
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// EventType represents type of progress event
+type EventType string
+
+const (
+	EventMilestone  EventType = "milestone"
+	EventStall      EventType = "stall"
+	EventSLABreach  EventType = "sla_breach"
+	EventCompletion EventType = "completion"
+)
+
+// Event represents progress event
+type Event struct {
+	Type EventType `json:"type"`
+
+	// Reached milestone in percents, set for milestone events only
+	Milestone float64 `json:"milestone,omitempty"`
+
+	// Calculator state at event moment
+	Snapshot Snapshot `json:"snapshot"`
+}
+
+// Notifier represents receiver of progress events
+type Notifier interface {
+	// Notify delivers event, ctx is canceled when calculator is closed
+	Notify(ctx context.Context, e Event) error
+}
+
+// WatchOptions represents progress watching params
+type WatchOptions struct {
+	// Progress check interval, one second if not positive
+	Interval time.Duration
+
+	// Processed percents to send milestone events at
+	Milestones []float64
+
+	// Duration without progress to send stall event after, zero value disables stall detection
+	StallTimeout time.Duration
+
+	// Time processing must finish before, zero value disables SLA breach detection
+	Deadline time.Time
+}
+
+// watcher represents progress watching state
+type watcher struct {
+	options    WatchOptions
+	milestones []float64
+
+	lastProcessed int
+	lastProgress  time.Time
+
+	stalled   bool
+	breached  bool
+	completed bool
+}
+
+// Watch starts goroutine which checks progress every interval and sends events
+// to notifier. Each milestone, SLA breach and completion is sent once,
+// stall is sent again only after progress is resumed.
+// Notify errors are passed to OnError. Watch does nothing after Close.
+func (ec *Calculator) Watch(options WatchOptions, notifier Notifier) {
+	w := &watcher{
+		options:      options,
+		milestones:   append([]float64(nil), options.Milestones...),
+		lastProgress: ec.now()}
+	sort.Float64s(w.milestones)
+
+	interval := options.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ec.spawn(func(done <-chan struct{}) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Cancel notifications in progress on Close
+		go func() {
+			select {
+			case <-done:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-done:
+				// Final events get own time limit as ctx is already canceled
+				flushCtx, cancelFlush := context.WithTimeout(context.Background(), defaultFlushTimeout)
+				w.check(ec.Snapshot(), ec.notify(flushCtx, notifier))
+				cancelFlush()
+				return
+			}
+
+			if w.check(ec.Snapshot(), ec.notify(ctx, notifier)) {
+				return
+			}
+		}
+	})
+}
+
+// notify returns function sending event to notifier and passing errors to OnError
+func (ec *Calculator) notify(ctx context.Context, notifier Notifier) func(Event) {
+	return func(e Event) {
+		ec.handleError(notifier.Notify(ctx, e))
+	}
+}
+
+// check sends events for snapshot and reports whether processing is completed
+func (w *watcher) check(s Snapshot, send func(Event)) bool {
+	if w.completed {
+		return true
+	}
+
+	for len(w.milestones) > 0 && s.Percent() >= w.milestones[0] {
+		send(Event{Type: EventMilestone, Milestone: w.milestones[0], Snapshot: s})
+		w.milestones = w.milestones[1:]
+	}
+
+	if s.TotalCount > 0 && s.Remaining() == 0 {
+		w.completed = true
+		send(Event{Type: EventCompletion, Snapshot: s})
+		return true
+	}
+
+	if s.Processed != w.lastProcessed {
+		w.lastProcessed = s.Processed
+		w.lastProgress = s.Time
+		w.stalled = false
+	} else if w.options.StallTimeout > 0 && !w.stalled && s.Time.Sub(w.lastProgress) >= w.options.StallTimeout {
+		w.stalled = true
+		send(Event{Type: EventStall, Snapshot: s})
+	}
+
+	if !w.options.Deadline.IsZero() && !w.breached {
+		if s.Time.After(w.options.Deadline) || s.Eta.After(w.options.Deadline) {
+			w.breached = true
+			send(Event{Type: EventSLABreach, Snapshot: s})
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package eta
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestWatcherCheck(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	snapshot := func(seconds, processed int, eta time.Time) Snapshot {
+		return Snapshot{
+			Time:       start.Add(time.Duration(seconds) * time.Second),
+			Processed:  processed,
+			TotalCount: 100,
+			Eta:        eta}
+	}
+
+	tests := []struct {
+		name      string
+		options   WatchOptions
+		snapshots []Snapshot
+		want      []Event
+		completed bool
+	}{
+		{
+			name:      "milestones in order",
+			options:   WatchOptions{Milestones: []float64{50, 10, 25}},
+			snapshots: []Snapshot{snapshot(1, 30, time.Time{}), snapshot(2, 60, time.Time{}), snapshot(3, 70, time.Time{})},
+			want:      []Event{{Type: EventMilestone, Milestone: 10}, {Type: EventMilestone, Milestone: 25}, {Type: EventMilestone, Milestone: 50}}},
+		{
+			name:    "stall re-armed by progress",
+			options: WatchOptions{StallTimeout: 10 * time.Second},
+			snapshots: []Snapshot{
+				snapshot(0, 10, time.Time{}),
+				snapshot(10, 10, time.Time{}),
+				snapshot(20, 10, time.Time{}),
+				snapshot(21, 20, time.Time{}),
+				snapshot(31, 20, time.Time{})},
+			want: []Event{{Type: EventStall}, {Type: EventStall}}},
+		{
+			name:    "SLA breach sent once",
+			options: WatchOptions{Deadline: start.Add(time.Minute)},
+			snapshots: []Snapshot{
+				snapshot(1, 10, start.Add(30*time.Second)),
+				snapshot(2, 20, start.Add(2*time.Minute)),
+				snapshot(3, 30, start.Add(3*time.Minute))},
+			want: []Event{{Type: EventSLABreach}}},
+		{
+			name:      "completion stops checks",
+			options:   WatchOptions{Milestones: []float64{100}, StallTimeout: time.Second},
+			snapshots: []Snapshot{snapshot(1, 100, time.Time{}), snapshot(10, 100, time.Time{})},
+			want:      []Event{{Type: EventMilestone, Milestone: 100}, {Type: EventCompletion}},
+			completed: true},
+	}
+
+	for _, test := range tests {
+		w := &watcher{
+			options:      test.options,
+			milestones:   append([]float64(nil), test.options.Milestones...),
+			lastProgress: start}
+		sort.Float64s(w.milestones)
+
+		var got []Event
+		var completed bool
+		for _, s := range test.snapshots {
+			completed = w.check(s, func(e Event) { got = append(got, Event{Type: e.Type, Milestone: e.Milestone}) })
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: events = %v, want %v", test.name, got, test.want)
+		}
+
+		if completed != test.completed {
+			t.Errorf("%s: completed = %v, want %v", test.name, completed, test.completed)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package eta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Webhook represents notifier which posts JSON encoded events to webhook URLs
+type Webhook struct {
+	urls []string
+
+	// HTTP client used for requests, client with 10 seconds timeout if nil
+	Client *http.Client
+
+	// Number of retries after failed request, negative value means no retries
+	Retries int
+
+	// Delay before first retry, doubled on each next retry
+	RetryDelay time.Duration
+}
+
+// NewWebhook returns new webhook notifier posting events to urls
+func NewWebhook(urls ...string) *Webhook {
+	return &Webhook{
+		urls:       urls,
+		Client:     &http.Client{Timeout: defaultWebhookTimeout},
+		Retries:    defaultWebhookRetries,
+		RetryDelay: defaultWebhookRetryDelay}
+}
+
+// Notify posts JSON encoded event to every URL.
+// Returns first error of requests failed after all retries.
+func (w *Webhook) Notify(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+
+	var firstErr error
+	for _, url := range w.urls {
+		if err := w.post(ctx, url, body); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// post posts body to url with retries, waiting between retries is interrupted by ctx
+func (w *Webhook) post(ctx context.Context, url string, body []byte) error {
+	delay := w.RetryDelay
+
+	retries := w.Retries
+	if retries < 0 {
+		retries = 0
+	}
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("webhook: %s: %w", url, ctx.Err())
+			}
+			delay *= 2
+		}
+
+		var retry bool
+		retry, err = w.postOnce(ctx, url, body)
+		if err == nil || !retry {
+			break
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("webhook: %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// postOnce posts body to url and reports whether failed request may be retried
+func (w *Webhook) postOnce(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	// Body is drained so connection can be reused
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+
+	return retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
@@ -0,0 +1,71 @@
+package eta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookPost(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		wantErr  bool
+		requests int32
+	}{
+		{"success", []int{200}, 3, false, 1},
+		{"retry server error", []int{500, 200}, 3, false, 2},
+		{"retry too many requests", []int{429, 503, 204}, 3, false, 3},
+		{"no retry on client error", []int{400, 200}, 3, true, 1},
+		{"retries exhausted", []int{500, 500, 500}, 2, true, 3},
+		{"negative retries", []int{500, 200}, -1, true, 1},
+	}
+
+	for _, test := range tests {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&requests, 1)
+			w.WriteHeader(test.statuses[int(n)-1])
+		}))
+
+		webhook := &Webhook{Retries: test.retries, RetryDelay: time.Millisecond}
+		err := webhook.post(context.Background(), server.URL, []byte("{}"))
+		server.Close()
+
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: post() error = %v, want error %v", test.name, err, test.wantErr)
+		}
+
+		if requests != test.requests {
+			t.Errorf("%s: %d requests, want %d", test.name, requests, test.requests)
+		}
+	}
+}
+
+func TestWebhookPostCanceled(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	webhook := NewWebhook(server.URL)
+	webhook.RetryDelay = time.Hour
+
+	err := webhook.post(ctx, server.URL, []byte("{}"))
+	if err == nil || ctx.Err() == nil {
+		t.Fatalf("post() error = %v, want context error", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("%d requests, want 1", requests)
+	}
+}